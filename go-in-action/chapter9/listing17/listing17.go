@@ -2,16 +2,32 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 
 	"github.com/IPyandy/go-in-action/chapter9/listing17/handlers"
+	"github.com/IPyandy/goplay/pkg/observability"
 )
 
+// serviceName identifies this server in traces and metrics.
+const serviceName = "listing17"
+
 // main is the entry point for the application.
 func main() {
+	shutdownTracing, err := observability.Init(context.Background(), serviceName)
+	if err != nil {
+		log.Fatalf("init tracing: %v", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Printf("shutdown tracing: %v", err)
+		}
+	}()
+
 	handlers.Routes()
+	http.Handle("/metrics", observability.MetricsHandler())
 
 	log.Println("listener : Started : Listening on :4000")
-	http.ListenAndServe(":4000", nil)
+	log.Fatal(http.ListenAndServe(":4000", observability.Middleware(serviceName, http.DefaultServeMux)))
 }
@@ -0,0 +1,156 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config loads configuration files that mix static data with
+// templating: a file is first rendered through text/template with a
+// small set of Helm/Grafana-style helpers (env, default, file, require),
+// then unmarshaled into a Go struct based on the file's extension, and
+// finally validated via struct tags.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-playground/validator/v10"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var validate = validator.New()
+
+// options holds the behavior flags Load and Watch share.
+type options struct {
+	strict bool
+}
+
+// Option customizes Load and Watch.
+type Option func(*options)
+
+// Strict rejects unknown fields in the source file instead of silently
+// ignoring them.
+func Strict() Option {
+	return func(o *options) { o.strict = true }
+}
+
+// Load reads the file at path, renders it through text/template with the
+// env/default/file/require helpers, unmarshals the result into out based
+// on the file's extension (.yaml/.yml, .json, .toml), and validates out
+// using its `validate` struct tags.
+func Load(path string, out interface{}, opts ...Option) error {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	rendered, err := render(path)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	if err := unmarshal(path, rendered, out, o.strict); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	if err := validate.Struct(out); err != nil {
+		return fmt.Errorf("config: validation failed: %w", err)
+	}
+
+	return nil
+}
+
+// render executes path as a text/template and returns the resulting
+// bytes.
+func render(path string) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	tpl, err := template.New(filepath.Base(path)).Funcs(funcMap()).Parse(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template %s: %w", path, err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tpl.Execute(buf, nil); err != nil {
+		return nil, fmt.Errorf("executing template %s: %w", path, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// funcMap returns the template helpers available to every config file.
+func funcMap() template.FuncMap {
+	return template.FuncMap{
+		"env": os.Getenv,
+		"default": func(fallback, val interface{}) interface{} {
+			if val == nil || val == "" {
+				return fallback
+			}
+			return val
+		},
+		"file": func(path string) (string, error) {
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return "", fmt.Errorf("file %s: %w", path, err)
+			}
+			return string(b), nil
+		},
+		"require": func(val interface{}) (interface{}, error) {
+			if val == nil || val == "" {
+				return nil, fmt.Errorf("required value is empty")
+			}
+			return val, nil
+		},
+	}
+}
+
+// unmarshal decodes data into out according to path's extension.
+func unmarshal(path string, data []byte, out interface{}, strict bool) error {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if strict {
+			return yaml.UnmarshalStrict(data, out)
+		}
+		return yaml.Unmarshal(data, out)
+
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		if strict {
+			dec.DisallowUnknownFields()
+		}
+		return dec.Decode(out)
+
+	case ".toml":
+		meta, err := toml.Decode(string(data), out)
+		if err != nil {
+			return err
+		}
+		if strict {
+			if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+				return fmt.Errorf("unknown fields: %v", undecoded)
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported config extension %q", ext)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Name string `yaml:"name" json:"name" validate:"required"`
+	Port int    `yaml:"port" json:"port"`
+}
+
+func writeFile(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+	return path
+}
+
+func TestLoadRendersTemplateHelpers(t *testing.T) {
+	t.Setenv("CONFIG_TEST_NAME", "from-env")
+	path := writeFile(t, t.TempDir(), "app.yaml", `
+name: {{ env "CONFIG_TEST_NAME" }}
+port: {{ default 8080 "" }}
+`)
+
+	var cfg testConfig
+	if err := Load(path, &cfg); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Name != "from-env" {
+		t.Errorf("Name = %q, want %q", cfg.Name, "from-env")
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("Port = %d, want %d", cfg.Port, 8080)
+	}
+}
+
+func TestLoadRequireFailsOnEmpty(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "app.yaml", `name: {{ require "" }}`)
+
+	var cfg testConfig
+	if err := Load(path, &cfg); err == nil {
+		t.Fatal("Load() error = nil, want an error from the require helper")
+	}
+}
+
+func TestLoadStrictRejectsUnknownFields(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "app.yaml", "name: ok\nport: 1\nbogus: surprise\n")
+
+	var cfg testConfig
+	if err := Load(path, &cfg); err != nil {
+		t.Fatalf("non-strict Load() error = %v, want nil", err)
+	}
+
+	if err := Load(path, &cfg, Strict()); err == nil {
+		t.Fatal("strict Load() error = nil, want an error for the unknown field")
+	}
+}
+
+func TestLoadValidatesStructTags(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "app.yaml", "port: 1\n")
+
+	var cfg testConfig
+	if err := Load(path, &cfg); err == nil {
+		t.Fatal("Load() error = nil, want a validation error for the missing required name")
+	}
+}
+
+func TestLoadUnsupportedExtension(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "app.ini", "name=ok\n")
+
+	var cfg testConfig
+	if err := Load(path, &cfg); err == nil {
+		t.Fatal("Load() error = nil, want an error for an unsupported extension")
+	}
+}
+
+func TestWatchReloadsOnWrite(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "app.yaml", "name: v1\nport: 1\n")
+
+	var cfg testConfig
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := Watch(ctx, path, &cfg)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if cfg.Name != "v1" {
+		t.Fatalf("initial Name = %q, want %q", cfg.Name, "v1")
+	}
+
+	writeFile(t, filepath.Dir(path), filepath.Base(path), "name: v2\nport: 2\n")
+
+	select {
+	case next := <-updates:
+		got := next.(*testConfig)
+		if got.Name != "v2" {
+			t.Fatalf("reloaded Name = %q, want %q", got.Name, "v2")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watch() did not deliver an update after the file was written")
+	}
+}
+
+func TestWatchSurvivesAtomicRename(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "app.yaml", "name: v1\nport: 1\n")
+
+	var cfg testConfig
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := Watch(ctx, path, &cfg)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	// Simulate the atomic rename-over pattern used by editors and k8s
+	// ConfigMap symlink swaps: write to a sibling file, then rename it
+	// over path. This replaces path's inode out from under the watch.
+	tmp := writeFile(t, dir, "app.yaml.tmp", "name: v2\nport: 2\n")
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatalf("renaming over %s: %v", path, err)
+	}
+
+	select {
+	case next := <-updates:
+		got := next.(*testConfig)
+		if got.Name != "v2" {
+			t.Fatalf("reloaded Name = %q, want %q", got.Name, "v2")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watch() did not survive the atomic rename-over")
+	}
+}
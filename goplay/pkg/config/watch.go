@@ -0,0 +1,125 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reAddRetries and reAddDelay bound how long Watch waits for a path to
+// reappear after an atomic replace (rename-over, as editors and k8s
+// ConfigMap symlink swaps do) before giving up on that occurrence.
+const (
+	reAddRetries = 10
+	reAddDelay   = 100 * time.Millisecond
+)
+
+// Watch loads path into out, then keeps watching path for writes,
+// sending a freshly loaded value of out's type on the returned channel
+// each time the file changes. The channel is closed when ctx is
+// done. Load errors encountered after the first load are logged and
+// otherwise ignored, so a momentarily invalid file doesn't stop the
+// watch.
+func Watch(ctx context.Context, path string, out interface{}, opts ...Option) (<-chan interface{}, error) {
+	if err := Load(path, out, opts...); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("config: watching %s: %w", path, err)
+	}
+	if err := watcher.Add(path); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("config: watching %s: %w", path, err)
+	}
+
+	values := make(chan interface{})
+	outType := reflect.TypeOf(out).Elem()
+
+	go func() {
+		defer watcher.Close()
+		defer close(values)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+					// The old inode is gone and the watch on it died
+					// with it; re-arm on the path the replacement
+					// landed at before treating this as a reload.
+					if !reAddWatch(ctx, watcher, path) {
+						log.Printf("config: lost watch on %s and could not re-arm it", path)
+						continue
+					}
+				} else if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+
+				next := reflect.New(outType).Interface()
+				if err := Load(path, next, opts...); err != nil {
+					log.Printf("config: reload %s: %v", path, err)
+					continue
+				}
+
+				select {
+				case values <- next:
+				case <-ctx.Done():
+					return
+				}
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("config: watch %s: %v", path, err)
+			}
+		}
+	}()
+
+	return values, nil
+}
+
+// reAddWatch retries watcher.Add(path) until it succeeds, ctx is done,
+// or reAddRetries is exhausted, giving a replacement file time to land
+// after an atomic rename-over.
+func reAddWatch(ctx context.Context, watcher *fsnotify.Watcher, path string) bool {
+	for i := 0; i < reAddRetries; i++ {
+		if err := watcher.Add(path); err == nil {
+			return true
+		}
+
+		select {
+		case <-time.After(reAddDelay):
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,185 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const fullBody = "the quick brown fox jumps over the lazy dog"
+
+var fullSHA256 = func() string {
+	sum := sha256.Sum256([]byte(fullBody))
+	return hex.EncodeToString(sum[:])
+}()
+
+func servingContent(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "fox.txt", time.Unix(1577836800, 0), strings.NewReader(body))
+	}))
+}
+
+func TestDownloadFresh(t *testing.T) {
+	srv := servingContent(t, fullBody)
+	defer srv.Close()
+
+	dest := filepath.Join(t.TempDir(), "fox.txt")
+
+	res, err := Download(context.Background(), srv.URL, dest, WithSHA256(fullSHA256))
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if res.BytesWritten != int64(len(fullBody)) {
+		t.Fatalf("BytesWritten = %d, want %d", res.BytesWritten, len(fullBody))
+	}
+	if res.SHA256 != fullSHA256 {
+		t.Fatalf("SHA256 = %s, want %s", res.SHA256, fullSHA256)
+	}
+
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("reading dest: %v", err)
+	}
+	if string(got) != fullBody {
+		t.Fatalf("dest contents = %q, want %q", got, fullBody)
+	}
+}
+
+func TestDownloadResumesPartialFile(t *testing.T) {
+	srv := servingContent(t, fullBody)
+	defer srv.Close()
+
+	dest := filepath.Join(t.TempDir(), "fox.txt")
+	const have = 10
+	if err := os.WriteFile(dest, []byte(fullBody[:have]), 0o644); err != nil {
+		t.Fatalf("seeding partial file: %v", err)
+	}
+
+	res, err := Download(context.Background(), srv.URL, dest, WithSHA256(fullSHA256))
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if res.BytesWritten != int64(len(fullBody)-have) {
+		t.Fatalf("BytesWritten = %d, want %d", res.BytesWritten, len(fullBody)-have)
+	}
+	if res.SHA256 != fullSHA256 {
+		t.Fatalf("SHA256 = %s, want %s (resume must hash the whole file, not just the new bytes)", res.SHA256, fullSHA256)
+	}
+
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("reading dest: %v", err)
+	}
+	if string(got) != fullBody {
+		t.Fatalf("dest contents = %q, want %q", got, fullBody)
+	}
+}
+
+func TestDownloadRevalidatesCompleteFile(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.ServeContent(w, r, "fox.txt", time.Unix(1577836800, 0), strings.NewReader(fullBody))
+	}))
+	defer srv.Close()
+
+	dest := filepath.Join(t.TempDir(), "fox.txt")
+
+	if _, err := Download(context.Background(), srv.URL, dest); err != nil {
+		t.Fatalf("first Download() error = %v", err)
+	}
+
+	res, err := Download(context.Background(), srv.URL, dest)
+	if err != nil {
+		t.Fatalf("second Download() error = %v", err)
+	}
+	if !res.NotModified {
+		t.Fatalf("NotModified = false, want true once dest already holds the complete file")
+	}
+}
+
+func TestDownloadFallsBackToFreshOn416(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "fox.txt")
+	// A stale partial file longer than what the server actually has: the
+	// resume Range start falls past the end of the content, so the
+	// server rejects it with 416 and Download must restart from scratch
+	// rather than fail outright.
+	stale := strings.Repeat("x", len(fullBody)*2)
+	if err := os.WriteFile(dest, []byte(stale), 0o644); err != nil {
+		t.Fatalf("seeding stale file: %v", err)
+	}
+	if err := saveMetadata(dest, metadata{Size: int64(len(fullBody) * 3)}); err != nil {
+		t.Fatalf("seeding metadata: %v", err)
+	}
+
+	srv := servingContent(t, fullBody)
+	defer srv.Close()
+
+	res, err := Download(context.Background(), srv.URL, dest, WithSHA256(fullSHA256))
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if res.SHA256 != fullSHA256 {
+		t.Fatalf("SHA256 = %s, want %s", res.SHA256, fullSHA256)
+	}
+
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("reading dest: %v", err)
+	}
+	if string(got) != fullBody {
+		t.Fatalf("dest contents = %q, want %q", got, fullBody)
+	}
+}
+
+func TestDownloadProgressClampsUnknownContentLength(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Force chunked transfer encoding so ContentLength arrives as -1:
+		// Flusher + no Content-Length header triggers net/http's chunked
+		// writer.
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte(fullBody))
+	}))
+	defer srv.Close()
+
+	dest := filepath.Join(t.TempDir(), "fox.txt")
+
+	var totals []int64
+	_, err := Download(context.Background(), srv.URL, dest, WithProgress(func(transferred, total int64) {
+		totals = append(totals, total)
+	}))
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+
+	if len(totals) == 0 {
+		t.Fatal("progress callback was never invoked")
+	}
+	for _, total := range totals {
+		if total < 0 {
+			t.Fatalf("progress total = %d, want it clamped to 0 when Content-Length is unknown", total)
+		}
+	}
+}
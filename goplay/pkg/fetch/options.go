@@ -0,0 +1,53 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import "net/http"
+
+// config holds the behavior flags Download accepts through Option.
+type config struct {
+	client       *http.Client
+	progress     func(transferred, total int64)
+	verifySHA256 string
+}
+
+// Option customizes a Download call.
+type Option func(*config)
+
+func defaultOptions() *config {
+	return &config{client: http.DefaultClient}
+}
+
+// WithClient overrides the *http.Client used to make the request, e.g.
+// to set a custom Transport or timeout.
+func WithClient(c *http.Client) Option {
+	return func(o *config) { o.client = c }
+}
+
+// WithProgress registers a callback invoked after every chunk written to
+// disk with the running transferred-bytes total and the expected size of
+// dest: the response's Content-Length, plus the bytes already on disk
+// when a transfer is resumed (0 if the server didn't send a length).
+func WithProgress(fn func(transferred, total int64)) Option {
+	return func(o *config) { o.progress = fn }
+}
+
+// WithSHA256 verifies that dest's final contents hash to the given
+// hex-encoded SHA-256 digest, computed as the file streams to disk
+// rather than by re-reading it afterward. Download returns an error if
+// the digest doesn't match.
+func WithSHA256(expectedHex string) Option {
+	return func(o *config) { o.verifySHA256 = expectedHex }
+}
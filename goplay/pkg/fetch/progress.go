@@ -0,0 +1,33 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fetch
+
+import "io"
+
+// progressWriter wraps a writer and reports cumulative bytes written
+// through onWrite, so a CLI can render a progress bar.
+type progressWriter struct {
+	w           io.Writer
+	onWrite     func(transferred, total int64)
+	total       int64
+	transferred int64
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.transferred += int64(n)
+	p.onWrite(p.transferred, p.total)
+	return n, err
+}
@@ -12,17 +12,100 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Package numerals converts between integers and classical Roman numerals.
 package numerals
 
-var Numerals = map[int]string{
-	10: "X",
-	9:  "IX",
-	8:  "VIII",
-	7:  "VII",
-	6:  "VI",
-	5:  "V",
-	4:  "IV",
-	3:  "III",
-	2:  "II",
-	1:  "I",
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MinValue and MaxValue bound the classical Roman numeral range this
+// package supports. Numerals outside this range have no standard
+// representation without additional notation (e.g. vinculum).
+const (
+	MinValue = 1
+	MaxValue = 3999
+)
+
+// ErrOutOfRange is returned by Encode when n falls outside [MinValue, MaxValue].
+var ErrOutOfRange = fmt.Errorf("numerals: value must be between %d and %d", MinValue, MaxValue)
+
+// ErrInvalidNumeral is returned by Parse when the input is not a
+// well-formed Roman numeral.
+var ErrInvalidNumeral = errors.New("numerals: invalid roman numeral")
+
+// symbol pairs a Roman numeral symbol with its integer value. table is
+// ordered from largest to smallest, and includes the subtractive forms
+// (CM, CD, XC, XL, IX, IV) alongside the additive ones so Encode and
+// Parse can share a single greedy pass.
+type symbol struct {
+	Value  int
+	Symbol string
+}
+
+var table = []symbol{
+	{1000, "M"},
+	{900, "CM"},
+	{500, "D"},
+	{400, "CD"},
+	{100, "C"},
+	{90, "XC"},
+	{50, "L"},
+	{40, "XL"},
+	{10, "X"},
+	{9, "IX"},
+	{5, "V"},
+	{4, "IV"},
+	{1, "I"},
+}
+
+// Encode converts n into its Roman numeral representation. It returns
+// ErrOutOfRange if n is not in [MinValue, MaxValue].
+func Encode(n int) (string, error) {
+	if n < MinValue || n > MaxValue {
+		return "", ErrOutOfRange
+	}
+
+	var b strings.Builder
+	for _, s := range table {
+		for n >= s.Value {
+			b.WriteString(s.Symbol)
+			n -= s.Value
+		}
+	}
+
+	return b.String(), nil
+}
+
+// Parse converts a Roman numeral string back into its integer value. It
+// returns ErrInvalidNumeral if s is not a well-formed numeral in
+// [MinValue, MaxValue].
+func Parse(s string) (int, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return 0, ErrInvalidNumeral
+	}
+
+	input := s
+	n := 0
+	for _, sym := range table {
+		for strings.HasPrefix(s, sym.Symbol) {
+			n += sym.Value
+			s = s[len(sym.Symbol):]
+		}
+	}
+	if s != "" {
+		return 0, ErrInvalidNumeral
+	}
+
+	// Round-trip the result to reject non-canonical forms such as
+	// "IIII" or "VV" that the greedy match above would otherwise accept.
+	canonical, err := Encode(n)
+	if err != nil || canonical != input {
+		return 0, ErrInvalidNumeral
+	}
+
+	return n, nil
 }
@@ -0,0 +1,140 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package router builds the simple-rest HTTP routes on top of gorilla/mux
+// so they can be exercised in tests without booting a listener.
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/IPyandy/goplay/pkg/net/http/simple-rest/middleware"
+	"github.com/IPyandy/goplay/pkg/net/http/simple-rest/numerals"
+	"github.com/IPyandy/goplay/pkg/observability"
+)
+
+// handlerTimeout bounds how long any single route may take to respond.
+const handlerTimeout = 5 * time.Second
+
+// serviceName identifies this server in traces and metrics.
+const serviceName = "simple-rest"
+
+// errorResponse is the JSON body returned for malformed requests.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeError writes a JSON error body with the given status code.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: msg})
+}
+
+// NewRouter builds the simple-rest route table with the standard
+// middleware chain (logging, panic recovery, CORS) and per-route
+// timeouts already applied, so callers can serve it as is.
+func NewRouter() *mux.Router {
+	r := mux.NewRouter()
+	r.Use(middleware.Logging, middleware.Recover, middleware.CORS, observability.MuxMiddleware(serviceName))
+
+	timeout := middleware.Timeout(handlerTimeout)
+	r.Handle("/roman_number/{number:[0-9]+}", timeout(http.HandlerFunc(romanNumberHandler))).Methods(http.MethodGet)
+	r.Handle("/arabic/{roman:[IVXLCDMivxlcdm]+}", timeout(http.HandlerFunc(arabicHandler))).Methods(http.MethodGet)
+	r.Handle("/roman_range/{a:[0-9]+}/{b:[0-9]+}", timeout(http.HandlerFunc(romanRangeHandler))).Methods(http.MethodGet)
+	r.Handle("/metrics", observability.MetricsHandler()).Methods(http.MethodGet)
+
+	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "unrecognized route: "+r.URL.Path)
+	})
+
+	return r
+}
+
+// romanNumberHandler serves GET /roman_number/{number}, converting an
+// integer in [numerals.MinValue, numerals.MaxValue] into its Roman
+// numeral form.
+func romanNumberHandler(w http.ResponseWriter, r *http.Request) {
+	// {number:[0-9]+} guarantees digits only. A value too large for an
+	// int makes Atoi fail and return the clamped maximum int, which
+	// Encode then rejects as out of range.
+	n, _ := strconv.Atoi(mux.Vars(r)["number"])
+	observability.AnnotateNumeral(r, n)
+
+	roman, err := numerals.Encode(n)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(roman)
+}
+
+// arabicHandler serves GET /arabic/{roman}, converting a Roman numeral
+// back into its integer value.
+func arabicHandler(w http.ResponseWriter, r *http.Request) {
+	n, err := numerals.Parse(mux.Vars(r)["roman"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	observability.AnnotateNumeral(r, n)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(n)
+}
+
+// romanRangeHandler serves GET /roman_range/{a}/{b}, returning the Roman
+// numeral form of every integer in [a, b] as a JSON array.
+func romanRangeHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	// {a:[0-9]+} and {b:[0-9]+} only guarantee digits, not that the
+	// value fits an int or falls within the numeral range, so errors
+	// must still be checked and the bounds validated before they're
+	// used for anything (including sizing the result slice below).
+	a, errA := strconv.Atoi(vars["a"])
+	b, errB := strconv.Atoi(vars["b"])
+	if errA != nil || errB != nil {
+		writeError(w, http.StatusBadRequest, "range bounds must be valid integers")
+		return
+	}
+	if a < numerals.MinValue || a > numerals.MaxValue || b < numerals.MinValue || b > numerals.MaxValue {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("range bounds must be between %d and %d", numerals.MinValue, numerals.MaxValue))
+		return
+	}
+	if a > b {
+		writeError(w, http.StatusBadRequest, "range start must not exceed range end")
+		return
+	}
+
+	results := make([]string, 0, b-a+1)
+	for n := a; n <= b; n++ {
+		roman, err := numerals.Encode(n)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		results = append(results, roman)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(results)
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRomanNumberHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "valid", path: "/roman_number/9", wantStatus: http.StatusOK, wantBody: `"IX"`},
+		{name: "out of range", path: "/roman_number/0", wantStatus: http.StatusNotFound},
+		{name: "above max", path: "/roman_number/4000", wantStatus: http.StatusNotFound},
+	}
+
+	r := NewRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doRequest(r, tt.path)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %q)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody+"\n" {
+				t.Fatalf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestArabicHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "valid", path: "/arabic/IX", wantStatus: http.StatusOK, wantBody: "9"},
+		{name: "non-canonical", path: "/arabic/IIII", wantStatus: http.StatusBadRequest},
+	}
+
+	r := NewRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doRequest(r, tt.path)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %q)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody+"\n" {
+				t.Fatalf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRomanRangeHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "valid", path: "/roman_range/1/5", wantStatus: http.StatusOK},
+		{name: "start after end", path: "/roman_range/5/1", wantStatus: http.StatusBadRequest},
+		{name: "end above max", path: "/roman_range/1/4000", wantStatus: http.StatusBadRequest},
+		// Regression test: a huge-but-numeric upper bound must be
+		// rejected before it ever reaches make([]string, 0, b-a+1),
+		// which previously crashed the process with an out-of-memory
+		// fatal error that middleware.Recover cannot catch.
+		{name: "huge upper bound", path: "/roman_range/1/10000000000", wantStatus: http.StatusBadRequest},
+	}
+
+	r := NewRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doRequest(r, tt.path)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %q)", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+
+	t.Run("valid body is the numeral array", func(t *testing.T) {
+		w := doRequest(r, "/roman_range/1/3")
+
+		var got []string
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("unmarshaling body %q: %v", w.Body.String(), err)
+		}
+
+		want := []string{"I", "II", "III"}
+		if len(got) != len(want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		}
+	})
+}
+
+func TestNotFound(t *testing.T) {
+	r := NewRouter()
+	w := doRequest(r, "/nope")
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func doRequest(r http.Handler, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
@@ -15,42 +15,54 @@
 package main
 
 import (
-	"fmt"
-	"html"
+	"context"
+	"log"
 	"net/http"
-	"strconv"
-	"strings"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
-	"github.com/IPyandy/goplay/pkg/net/http/simple-rest/numerals"
+	"github.com/IPyandy/goplay/pkg/net/http/simple-rest/router"
+	"github.com/IPyandy/goplay/pkg/observability"
 )
 
+// serviceName identifies this server in traces.
+const serviceName = "simple-rest"
+
 func main() {
-	// http package has methods for dealing with requests
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		urlPathElements := strings.Split(r.URL.Path, "/")
-		// If request is GET with correct syntax
-		if urlPathElements[1] == "roman_number" {
-			number, _ := strconv.Atoi(strings.TrimSpace(urlPathElements[2]))
-			if number == 0 || number > 10 {
-				// If resource is not in the list, send Not Found status
-				w.WriteHeader(http.StatusNotFound)
-				_, _ = w.Write([]byte("404 - Not Found"))
-			} else {
-				fmt.Fprintf(w, "%q", html.EscapeString(numerals.Numerals[number]))
-			}
-		} else {
-			// For all other requests, tell that Client sent a bad request
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte("400 - Bad request"))
-		}
-	})
-	// Create a server and run it on 8000 port
+	shutdownTracing, err := observability.Init(context.Background(), serviceName)
+	if err != nil {
+		log.Fatalf("init tracing: %v", err)
+	}
+
 	s := &http.Server{
 		Addr:           ":8000",
+		Handler:        router.NewRouter(),
 		ReadTimeout:    10 * time.Second,
 		WriteTimeout:   10 * time.Second,
 		MaxHeaderBytes: 1 << 20,
 	}
-	_ = s.ListenAndServe()
+
+	go func() {
+		if err := s.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("listen: %v", err)
+		}
+	}()
+	log.Println("listening on :8000")
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.Shutdown(ctx); err != nil {
+		log.Fatalf("shutdown: %v", err)
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("shutdown tracing: %v", err)
+	}
+	log.Println("server stopped")
 }
@@ -15,42 +15,28 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"io"
-	"net"
-	"os"
+
+	"github.com/IPyandy/goplay/pkg/fetch"
 )
 
 func main() {
+	ctx := context.Background()
+	url := "https://www.gutenberg.org/cache/epub/16328/pg16328.txt"
 
-	host, port := "www.gutenberg.org", "80"
-	addr := net.JoinHostPort(host, port)
-	httpRequest := "GET  /cache/epub/16328/pg16328.txt HTTP/1.1\n" +
-		"Host: " + host + "\n\n"
-
-	conn, err := net.Dial("tcp", addr)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-	defer conn.Close()
-
-	if _, err = conn.Write([]byte(httpRequest)); err != nil {
-		fmt.Println(err)
-		return
-	}
-
-	file, err := os.Create("beowulf.txt")
+	result, err := fetch.Download(ctx, url, "beowulf.txt", fetch.WithProgress(func(transferred, total int64) {
+		fmt.Printf("\r%d/%d bytes", transferred, total)
+	}))
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
-	defer file.Close()
 
-	if _, err = io.Copy(file, conn); err != nil {
-		fmt.Println(err)
+	if result.NotModified {
+		fmt.Println("\nbeowulf.txt already up to date")
 		return
 	}
 
-	fmt.Printf("\nText copied to file %v\n", file.Name())
+	fmt.Printf("\nText copied to file beowulf.txt (%d bytes, sha256 %s)\n", result.BytesWritten, result.SHA256)
 }
@@ -0,0 +1,48 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observability
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total HTTP requests, by route and status code.",
+	}, []string{"route", "status"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP handler latency in seconds, by route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route"})
+)
+
+// ObserveRequest records a completed request against the requestsTotal
+// counter and requestDuration histogram.
+func ObserveRequest(route, status string, seconds float64) {
+	requestsTotal.WithLabelValues(route, status).Inc()
+	requestDuration.WithLabelValues(route).Observe(seconds)
+}
+
+// MetricsHandler serves the Prometheus exposition format for /metrics.
+func MetricsHandler() http.Handler {
+	return promhttp.Handler()
+}
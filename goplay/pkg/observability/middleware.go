@@ -0,0 +1,87 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observability
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NumeralAttributeKey is the span attribute set by AnnotateNumeral.
+const NumeralAttributeKey = attribute.Key("numeral.value")
+
+// AnnotateNumeral records the parsed numeral value on the span active in
+// the handler's request context, if any.
+func AnnotateNumeral(r *http.Request, value int) {
+	trace.SpanFromContext(r.Context()).SetAttributes(NumeralAttributeKey.Int(value))
+}
+
+// MuxMiddleware returns a gorilla/mux middleware chain that traces every
+// request with otelmux and records Prometheus counters and latency
+// keyed by the matched route template.
+func MuxMiddleware(serviceName string) mux.MiddlewareFunc {
+	traced := otelmux.Middleware(serviceName)
+
+	return func(next http.Handler) http.Handler {
+		return traced(recordMetrics(next))
+	}
+}
+
+// Middleware wraps next with otelhttp tracing and Prometheus metrics for
+// servers that don't use gorilla/mux.
+func Middleware(serviceName string, next http.Handler) http.Handler {
+	return otelhttp.NewHandler(recordMetrics(next), serviceName)
+}
+
+// recordMetrics wraps next so every request updates requestsTotal and
+// requestDuration, labeled by the matched mux route template when one is
+// available and by the request path otherwise.
+func recordMetrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if tpl, err := current.GetPathTemplate(); err == nil {
+				route = tpl
+			}
+		}
+
+		trace.SpanFromContext(r.Context()).SetAttributes(attribute.Int("http.status_code", rec.status))
+		ObserveRequest(route, strconv.Itoa(rec.status), time.Since(start).Seconds())
+	})
+}
+
+// statusRecorder captures the status code written by a downstream
+// handler so recordMetrics can report it after the fact.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,70 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package observability provides the tracing and metrics setup shared by
+// the example HTTP servers in this repository: an OpenTelemetry tracer
+// provider exporting over OTLP/HTTP, and a Prometheus registry exposing
+// request counters and a handler latency histogram.
+package observability
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Shutdown flushes and stops everything Init started. Callers should
+// defer it and pass a context with a reasonable timeout.
+type Shutdown func(ctx context.Context) error
+
+// Init configures the global OpenTelemetry tracer provider for
+// serviceName. If OTEL_EXPORTER_OTLP_ENDPOINT is unset, spans are
+// recorded but not exported, which keeps the examples runnable without
+// a collector on hand.
+func Init(ctx context.Context, serviceName string) (Shutdown, error) {
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		exp, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	}
+
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// Tracer returns the named tracer used by the example servers to start
+// handler spans.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
@@ -0,0 +1,103 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pretty
+
+import "strings"
+
+// Diff renders a and b with Sprint and returns a unified, line-based
+// diff between the two: unchanged lines are prefixed with two spaces,
+// lines only in a with "- ", and lines only in b with "+ ". It is meant
+// for test failure messages, not as a structural patch format.
+func Diff(a, b interface{}) string {
+	linesA := strings.Split(Sprint(a), "\n")
+	linesB := strings.Split(Sprint(b), "\n")
+
+	ops := diffLines(linesA, linesB)
+	if allEqual(ops) {
+		return ""
+	}
+
+	var out strings.Builder
+	for i, op := range ops {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString(op.prefix)
+		out.WriteString(op.line)
+	}
+
+	return out.String()
+}
+
+type diffOp struct {
+	prefix string
+	line   string
+}
+
+func allEqual(ops []diffOp) bool {
+	for _, op := range ops {
+		if op.prefix != "  " {
+			return false
+		}
+	}
+	return true
+}
+
+// diffLines computes a minimal line diff between a and b using the
+// standard longest-common-subsequence backtrack.
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{"  ", a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{"- ", a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{"+ ", b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{"- ", a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{"+ ", b[j]})
+	}
+
+	return ops
+}
@@ -0,0 +1,48 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pretty reflects over arbitrary values to print them for
+// humans: struct, slice, map, and pointer walking that follows json/yaml
+// tag names, masks fields tagged `pretty:"secret"`, and guards against
+// cycles through pointers it has already visited.
+package pretty
+
+import "reflect"
+
+// Option customizes Sprint.
+type Option func(*printer)
+
+// MaxDepth stops descending into nested values past n levels and prints
+// "…" instead. A MaxDepth of 0 (the default) means unlimited.
+func MaxDepth(n int) Option {
+	return func(p *printer) { p.maxDepth = n }
+}
+
+// Compact renders the value on a single line instead of the default
+// indented, multi-line form.
+func Compact() Option {
+	return func(p *printer) { p.compact = true }
+}
+
+// Sprint renders v as a human-readable string, walking structs, slices,
+// maps, and pointers. Cycles through pointers are detected and printed
+// as "<cycle>" rather than recursing forever.
+func Sprint(v interface{}, opts ...Option) string {
+	p := &printer{visited: map[visitKey]bool{}}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p.sprint(reflect.ValueOf(v), 0)
+}
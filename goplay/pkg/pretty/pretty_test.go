@@ -0,0 +1,130 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pretty
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// timeout bounds the cycle tests below: if the fix regresses, Sprint
+// recurses forever instead of returning, and the test must fail cleanly
+// rather than hang the test binary.
+func timeout() <-chan time.Time {
+	return time.After(2 * time.Second)
+}
+
+type secretUser struct {
+	ID       string
+	Password string `pretty:"secret"`
+}
+
+func TestSprintCompact(t *testing.T) {
+	u := &secretUser{ID: "1234", Password: "hunter2"}
+
+	got := Sprint(u, Compact())
+	want := `secretUser{ID: 1234, Password: ***}`
+	if got != want {
+		t.Fatalf("Sprint() = %q, want %q", got, want)
+	}
+}
+
+func TestSprintMultiline(t *testing.T) {
+	u := &secretUser{ID: "1234", Password: "hunter2"}
+
+	got := Sprint(u)
+	for _, want := range []string{"ID: 1234", "Password: ***"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("Sprint() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestSprintMaxDepth(t *testing.T) {
+	type inner struct{ V int }
+	type outer struct{ Inner inner }
+
+	got := Sprint(outer{Inner: inner{V: 1}}, MaxDepth(1), Compact())
+	want := `outer{Inner: inner{V: …}}`
+	if got != want {
+		t.Fatalf("Sprint() = %q, want %q", got, want)
+	}
+}
+
+func TestSprintPointerCycle(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+
+	a := &node{Name: "a"}
+	a.Next = a
+
+	got := Sprint(a, Compact())
+	want := `node{Name: a, Next: <cycle>}`
+	if got != want {
+		t.Fatalf("Sprint() = %q, want %q", got, want)
+	}
+}
+
+func TestSprintMapCycle(t *testing.T) {
+	m := map[string]interface{}{}
+	m["self"] = m
+
+	done := make(chan string, 1)
+	go func() { done <- Sprint(m) }()
+
+	select {
+	case got := <-done:
+		if !strings.Contains(got, "<cycle>") {
+			t.Fatalf("Sprint() = %q, want it to contain <cycle>", got)
+		}
+	case <-timeout():
+		t.Fatal("Sprint() did not return; self-referential map likely recurses forever")
+	}
+}
+
+func TestSprintSliceCycle(t *testing.T) {
+	s := make([]interface{}, 1)
+	s[0] = s
+
+	done := make(chan string, 1)
+	go func() { done <- Sprint(s) }()
+
+	select {
+	case got := <-done:
+		if !strings.Contains(got, "<cycle>") {
+			t.Fatalf("Sprint() = %q, want it to contain <cycle>", got)
+		}
+	case <-timeout():
+		t.Fatal("Sprint() did not return; self-referential slice likely recurses forever")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	type person struct{ Name string }
+
+	got := Diff(person{Name: "a"}, person{Name: "b"})
+	for _, want := range []string{"-   Name: a", "+   Name: b"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("Diff() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	if Diff(person{Name: "a"}, person{Name: "a"}) != "" {
+		t.Fatal("Diff() of equal values should be empty")
+	}
+}
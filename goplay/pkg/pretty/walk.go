@@ -0,0 +1,214 @@
+// Copyright 2019 Yandy Ramirez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pretty
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const maskedValue = "***"
+
+// printer holds the state threaded through a single Sprint call.
+type printer struct {
+	maxDepth int
+	compact  bool
+	visited  map[visitKey]bool
+}
+
+// visitKey identifies a pointer-like value by its address and type, so
+// the same address reused for a different type isn't mistaken for a
+// cycle.
+type visitKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+func (p *printer) sprint(v reflect.Value, depth int) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+
+	if p.maxDepth > 0 && depth > p.maxDepth {
+		return "…"
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return p.sprintPointer(v, depth)
+
+	case reflect.Struct:
+		return p.sprintStruct(v, depth)
+
+	case reflect.Slice, reflect.Array:
+		return p.sprintSequence(v, depth)
+
+	case reflect.Map:
+		return p.sprintMap(v, depth)
+
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
+
+func (p *printer) sprintPointer(v reflect.Value, depth int) string {
+	if v.IsNil() {
+		return "nil"
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if release, cyclic := p.enter(v); cyclic {
+			return "<cycle>"
+		} else {
+			defer release()
+		}
+	}
+
+	return p.sprint(v.Elem(), depth)
+}
+
+// enter registers v in the visited set if it's a reference type that can
+// participate in a cycle (Ptr, Slice, Map all expose .Pointer()) and
+// reports whether v was already visited. release must be called once
+// the caller is done recursing into v's elements; it's a no-op for
+// kinds enter doesn't track.
+func (p *printer) enter(v reflect.Value) (release func(), cyclic bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+	default:
+		return func() {}, false
+	}
+
+	if v.Pointer() == 0 {
+		return func() {}, false
+	}
+
+	key := visitKey{addr: v.Pointer(), typ: v.Type()}
+	if p.visited[key] {
+		return func() {}, true
+	}
+
+	p.visited[key] = true
+	return func() { delete(p.visited, key) }, false
+}
+
+func (p *printer) sprintStruct(v reflect.Value, depth int) string {
+	t := v.Type()
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" { // unexported
+			continue
+		}
+
+		name, secret := fieldName(f)
+		var value string
+		if secret {
+			value = maskedValue
+		} else {
+			value = p.sprint(v.Field(i), depth+1)
+		}
+
+		fields = append(fields, name+": "+value)
+	}
+
+	return wrap(t.Name(), fields, depth, p.compact)
+}
+
+// fieldName resolves the display name for f, preferring a json tag,
+// then a yaml tag, then the Go field name, and reports whether f is
+// tagged `pretty:"secret"`.
+func fieldName(f reflect.StructField) (name string, secret bool) {
+	name = f.Name
+
+	if tag, ok := f.Tag.Lookup("json"); ok {
+		if n := strings.Split(tag, ",")[0]; n != "" && n != "-" {
+			name = n
+		}
+	} else if tag, ok := f.Tag.Lookup("yaml"); ok {
+		if n := strings.Split(tag, ",")[0]; n != "" && n != "-" {
+			name = n
+		}
+	}
+
+	if tag, ok := f.Tag.Lookup("pretty"); ok {
+		for _, opt := range strings.Split(tag, ",") {
+			if opt == "secret" {
+				secret = true
+			}
+		}
+	}
+
+	return name, secret
+}
+
+func (p *printer) sprintSequence(v reflect.Value, depth int) string {
+	if release, cyclic := p.enter(v); cyclic {
+		return "<cycle>"
+	} else {
+		defer release()
+	}
+
+	elems := make([]string, v.Len())
+	for i := range elems {
+		elems[i] = p.sprint(v.Index(i), depth+1)
+	}
+
+	return wrap("", elems, depth, p.compact)
+}
+
+func (p *printer) sprintMap(v reflect.Value, depth int) string {
+	if release, cyclic := p.enter(v); cyclic {
+		return "<cycle>"
+	} else {
+		defer release()
+	}
+
+	keys := v.MapKeys()
+	rendered := make([]string, len(keys))
+	for i, k := range keys {
+		rendered[i] = fmt.Sprintf("%v: %s", k.Interface(), p.sprint(v.MapIndex(k), depth+1))
+	}
+	sort.Strings(rendered)
+
+	return wrap("", rendered, depth, p.compact)
+}
+
+// wrap joins items into either "name{a, b, c}" (compact) or an indented
+// multi-line block.
+func wrap(name string, items []string, depth int, compact bool) string {
+	if compact || len(items) == 0 {
+		return name + "{" + strings.Join(items, ", ") + "}"
+	}
+
+	indent := strings.Repeat("  ", depth+1)
+	closeIndent := strings.Repeat("  ", depth)
+
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteString("{\n")
+	for _, item := range items {
+		b.WriteString(indent)
+		b.WriteString(item)
+		b.WriteString("\n")
+	}
+	b.WriteString(closeIndent)
+	b.WriteString("}")
+
+	return b.String()
+}
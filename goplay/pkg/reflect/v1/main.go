@@ -15,56 +15,40 @@
 package main
 
 import (
-	"bytes"
 	"fmt"
-	"reflect"
+
+	"github.com/IPyandy/goplay/pkg/pretty"
 )
 
 // user is the type we're playing with
 type user struct {
-	ID    string
-	Name  string
-	Email string
+	ID       string
+	Name     string
+	Email    string
+	Password string `pretty:"secret"`
 }
 
-// String implements the Stringer using the reflect package
+// String implements the Stringer using pretty.Sprint instead of a
+// hand-rolled reflect walk.
 func (u *user) String() string {
-	v := reflect.ValueOf(*u)
-
-	buf := &bytes.Buffer{}
-	for i := 0; i < v.NumField(); i++ {
-		if i > 0 {
-			buf.WriteByte(' ')
-		}
-		fmt.Fprintf(buf, "(%s: %v)", v.Type().Field(i).Name, v.Field(i))
-	}
-
-	return buf.String()
+	return pretty.Sprint(u, pretty.Compact())
 }
 
 func main() {
-
 	in := &user{
-		ID:    "1234",
-		Name:  "ME TWO",
-		Email: "LOL@LMAFO.ROFL",
+		ID:       "1234",
+		Name:     "ME TWO",
+		Email:    "LOL@LMAFO.ROFL",
+		Password: "hunter2",
 	}
 
-	t := reflect.TypeOf(in)
-
-	fmt.Println()       //
-	fmt.Printf("%v", t) // Output: *main.user
-	fmt.Println()       //
-
-	v := reflect.ValueOf(in) //
-	fmt.Println()            //
-	fmt.Printf("%v", v)      //
-	// Output:
-	//	id: "1234"
-	// 	name: ME TWO
-	//	email: LOL@LMAFO.ROFL
+	fmt.Println()
+	fmt.Println(in) // Output: user{ID: 1234, Name: ME TWO, Email: LOL@LMAFO.ROFL, Password: ***}
 
-	fmt.Println()           //
-	fmt.Println(v.String()) // Output: <*main.user Value>
+	fmt.Println()
+	fmt.Println(pretty.Sprint(in)) // Output: multi-line indented form
 
+	other := &user{ID: "1234", Name: "ME TOO", Email: in.Email, Password: in.Password}
+	fmt.Println()
+	fmt.Println(pretty.Diff(in, other)) // Output: unified diff of the Name field
 }
@@ -15,13 +15,13 @@
 package main
 
 import (
-	"bytes"
 	"fmt"
-	"html/template"
-	"os"
+	"log"
 
 	"github.com/joho/godotenv"
 	yaml "gopkg.in/yaml.v2"
+
+	"github.com/IPyandy/goplay/pkg/config"
 )
 
 // init loads a local .env file if present
@@ -31,9 +31,9 @@ func init() {
 
 // user os the type we're playing with
 type user struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID    string `json:"id" validate:"required"`
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
 }
 
 // String implements the Stringer interface to use with fmt
@@ -44,29 +44,15 @@ func (u *user) String() string {
 }
 
 func main() {
-
-	// First we read in the ENV variables with the os package
-	in := &user{
-		ID:    os.Getenv("ID"),
-		Name:  os.Getenv("NAME"),
-		Email: os.Getenv("EMAIL"),
-	}
-
 	// create an empty != nil user to store the final user
 	out := &user{}
 
-	// parse the template file
-	tpl, _ := template.ParseFiles("user.tpl.yaml")
-
-	// create a empty != nil buffer to store the Executed template
-	buf := &bytes.Buffer{}
-
-	// execute the template and store the output in the buf variable
-	_ = tpl.Execute(buf, in)
-
-	// unmarshal the bytes from the buffer back into the out variable
-	// there has to be an easier way.
-	_ = yaml.Unmarshal(buf.Bytes(), out)
+	// user.yaml is rendered through text/template before being
+	// unmarshaled, so `{{ env "ID" }}`-style placeholders pull values
+	// straight from the environment.
+	if err := config.Load("user.yaml", out); err != nil {
+		log.Fatal(err)
+	}
 
 	fmt.Printf("\n%v\n", out)
 }
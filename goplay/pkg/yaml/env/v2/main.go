@@ -15,12 +15,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"io/ioutil"
-	"os"
+	"log"
+	"os/signal"
+	"syscall"
 
 	"github.com/joho/godotenv"
 	yaml "gopkg.in/yaml.v2"
+
+	"github.com/IPyandy/goplay/pkg/config"
 )
 
 // init loads a local .env file if present
@@ -30,9 +34,9 @@ func init() {
 
 // user os the type we're playing with
 type user struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID    string `json:"id" validate:"required"`
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
 }
 
 // String implements the Stringer interface to use with fmt
@@ -43,14 +47,23 @@ func (u *user) String() string {
 }
 
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	// create an empty != nil user to store the final user
 	out := &user{}
 
-	content, _ := ioutil.ReadFile("user.yaml")
-	content = []byte(os.ExpandEnv(string(content)))
-
-	_ = yaml.Unmarshal(content, out)
+	// user.tpl.yaml leans on the `default` and `require` template
+	// helpers. Unlike v1's one-shot Load, this watches the file and
+	// prints every subsequent edit until the process is interrupted.
+	updates, err := config.Watch(ctx, "user.tpl.yaml", out, config.Strict())
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	fmt.Printf("\n%v\n", out)
+
+	for u := range updates {
+		fmt.Printf("\nreloaded:\n%v\n", u.(*user))
+	}
 }